@@ -0,0 +1,80 @@
+package main
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Config holds user defaults loaded from the showbranches config file. It
+// sits beneath command line flags and environment variables: flags win,
+// then env vars, then config.
+type Config struct {
+	Base   []string
+	Ignore []string
+	// Columns selects and orders the table output's columns by key
+	// (directory, repo, main, current, ahead-behind, dirty, status); an
+	// unrecognized set falls back to the default columns. See
+	// tableColumns in output.go.
+	Columns []string
+}
+
+// loadConfig reads $XDG_CONFIG_HOME/showbranches/config, falling back to
+// ~/.config/showbranches/config. A missing file is not an error, it just
+// means there are no file-based defaults.
+func loadConfig() Config {
+	var c Config
+
+	p := configPath()
+	if p == "" {
+		return c
+	}
+
+	f, err := os.Open(p)
+	if err != nil {
+		return c
+	}
+	defer func() { _ = f.Close() }()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		key = strings.TrimSpace(key)
+		value = strings.TrimSpace(value)
+
+		switch key {
+		case "base":
+			c.Base = append(c.Base, strings.Fields(value)...)
+		case "ignore":
+			c.Ignore = append(c.Ignore, value)
+		case "columns":
+			c.Columns = strings.Split(value, ",")
+		}
+	}
+
+	return c
+}
+
+// configPath resolves the config file location, preferring
+// $XDG_CONFIG_HOME/showbranches/config and falling back to
+// ~/.config/showbranches/config. It returns "" if neither can be determined.
+func configPath() string {
+	if xdg := os.Getenv("XDG_CONFIG_HOME"); xdg != "" {
+		return filepath.Join(xdg, "showbranches", "config")
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".config", "showbranches", "config")
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ericfialkowski/env"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/go-git/go-git/v5/plumbing/transport/ssh"
+)
+
+// fetchRepo fetches origin into r, using auth appropriate to the remote's
+// scheme, and bounded by timeout so a hung remote can't stall the whole
+// scan. git.NoErrAlreadyUpToDate is not treated as an error.
+func fetchRepo(r *git.Repository, remoteURL string, timeout time.Duration) error {
+	auth, err := remoteAuth(remoteURL)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	err = r.FetchContext(ctx, &git.FetchOptions{RemoteName: "origin", Auth: auth})
+	if err != nil && !errors.Is(err, git.NoErrAlreadyUpToDate) {
+		return err
+	}
+	return nil
+}
+
+// remoteAuth picks an auth method for remoteURL based on its scheme. A nil,
+// nil result means "try anonymously" - fine for public HTTPS remotes.
+func remoteAuth(remoteURL string) (transport.AuthMethod, error) {
+	switch {
+	case strings.HasPrefix(remoteURL, "http://"), strings.HasPrefix(remoteURL, "https://"):
+		return httpsAuth(), nil
+	case strings.HasPrefix(remoteURL, "git@"), strings.HasPrefix(remoteURL, "ssh://"):
+		return sshAuth()
+	default:
+		return nil, nil
+	}
+}
+
+// httpsAuth builds basic auth from the SHOWBRANCHES_HTTPS_TOKEN env var, with
+// SHOWBRANCHES_HTTPS_USER as the username (default "git"). Returns nil if no
+// token is configured; git credential helpers and GIT_ASKPASS are not used.
+func httpsAuth() transport.AuthMethod {
+	token := env.StringOrDefault("SHOWBRANCHES_HTTPS_TOKEN", "")
+	if token == "" {
+		return nil
+	}
+	user := env.StringOrDefault("SHOWBRANCHES_HTTPS_USER", "git")
+	return &http.BasicAuth{Username: user, Password: token}
+}
+
+// sshAuth prefers the running ssh-agent (via SSH_AUTH_SOCK) and falls back
+// to the first of the user's default key files that exists.
+func sshAuth() (transport.AuthMethod, error) {
+	if os.Getenv("SSH_AUTH_SOCK") != "" {
+		if auth, err := ssh.NewSSHAgentAuth("git"); err == nil {
+			return auth, nil
+		}
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, nil
+	}
+
+	for _, name := range []string{"id_ed25519", "id_rsa", "id_ecdsa"} {
+		keyPath := filepath.Join(home, ".ssh", name)
+		if _, err := os.Stat(keyPath); err == nil {
+			return ssh.NewPublicKeysFromFile("git", keyPath, "")
+		}
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing/format/gitignore"
+)
+
+// ignoreFileName is the gitignore-style file that marks directories a scan
+// should skip, e.g. vendor or node_modules.
+const ignoreFileName = ".showbranchesignore"
+
+// loadIgnorePatterns builds a matcher from dir's .showbranchesignore file (if
+// present) plus any extra patterns supplied via the config file. It returns
+// nil if there are no patterns to apply.
+func loadIgnorePatterns(dir string, extra []string) gitignore.Matcher {
+	var patterns []gitignore.Pattern
+	for _, p := range extra {
+		patterns = append(patterns, gitignore.ParsePattern(p, nil))
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, ignoreFileName))
+	if err == nil {
+		for _, line := range strings.Split(string(data), "\n") {
+			line = strings.TrimSpace(line)
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			patterns = append(patterns, gitignore.ParsePattern(line, nil))
+		}
+	}
+
+	if len(patterns) == 0 {
+		return nil
+	}
+	return gitignore.NewMatcher(patterns)
+}
@@ -1,42 +1,79 @@
 package main
 
 import (
-	"errors"
 	"flag"
-	"github.com/ericfialkowski/env"
+	"fmt"
 	"os"
-	"path"
-	"path/filepath"
+	"runtime"
+	"strconv"
 	"strings"
+	"time"
 
-	"github.com/go-git/go-git/v5"
-	"github.com/go-git/go-git/v5/plumbing"
-	"github.com/pterm/pterm"
-)
-
-const (
-	DirHeader  = iota
-	RepoHeader = iota
-	MainHeader = iota
-	CurrHeader = iota
+	"github.com/ericfialkowski/env"
 )
 
 var (
-	header        = []string{"Directory", "Repo", "Main Branch", "Current Branch"}
 	onlyShowDiffs bool
 	showNoRemotes bool
+	statusMode    bool
+	recurseDepth  depthFlag
+	concurrency   int
+	outputFormat  string
+	fetchMode     bool
+	fetchTimeout  time.Duration
+	cfg           Config
 )
 
+// depthFlag implements flag.Value, plus the boolean-flag convention the flag
+// package looks for, so that "-r" alone means unlimited recursion while
+// "-r N" limits the walk to N levels below base.
+type depthFlag struct {
+	n int
+}
+
+func (d *depthFlag) String() string {
+	return strconv.Itoa(d.n)
+}
+
+func (d *depthFlag) Set(s string) error {
+	if s == "" || s == "true" {
+		d.n = -1
+		return nil
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return err
+	}
+	d.n = n
+	return nil
+}
+
+func (d *depthFlag) IsBoolFlag() bool {
+	return true
+}
+
 func main() {
-	defaultBase := env.StringOrDefault("SHOWBRANCHES_DEFAULT", ".")
+	cfg = loadConfig()
+
 	flag.BoolVar(&onlyShowDiffs, "d", false, "Only display dirs that are on different branched")
 	flag.BoolVar(&showNoRemotes, "l", false, "Include dirs without remote repositories")
+	flag.Var(&recurseDepth, "r", "Recurse into subdirectories; -r N limits the depth to N levels, -r alone recurses without limit")
+	flag.IntVar(&concurrency, "j", runtime.NumCPU(), "Number of repositories to scan concurrently")
+	flag.BoolVar(&statusMode, "s", false, "Show ahead/behind counts vs. the remote main branch and whether the worktree is dirty")
+	flag.StringVar(&outputFormat, "o", "table", "Output format: "+strings.Join(outputFormats, ", "))
+	flag.BoolVar(&fetchMode, "f", false, "Fetch each repo from its remote before reporting branch info")
+	flag.DurationVar(&fetchTimeout, "t", env.DurationOrDefault("SHOWBRANCHES_FETCH_TIMEOUT", 30*time.Second), "Timeout for each repo's fetch when -f is set")
 
-	flag.Parse()
+	if err := flag.CommandLine.Parse(preprocessArgs(os.Args[1:])); err != nil {
+		os.Exit(2)
+	}
+	if concurrency < 1 {
+		concurrency = 1
+	}
 	dirs := flag.Args()
 
 	if len(dirs) == 0 {
-		for _, s := range strings.Split(defaultBase, " ") {
+		for _, s := range defaultDirs() {
 			printData(s)
 		}
 		os.Exit(0)
@@ -46,82 +83,46 @@ func main() {
 	}
 }
 
-func printData(base string) {
-	data := getBranchInfo(base)
-	_ = pterm.DefaultTable.WithHasHeader().WithBoxed().WithData(data).Render()
+// preprocessArgs rewrites a space-separated "-r N" / "--r N" into "-r=N"
+// before the flag package sees it. recurseDepth.IsBoolFlag is true so that
+// bare "-r" means unlimited recursion, but that same boolFlag convention
+// means the flag package never consumes a following "N" as its value - it
+// would be left as a stray positional directory argument instead. Rewriting
+// it to "-r=N" up front gives callers the documented "-r N" syntax without
+// giving up "-r" alone.
+func preprocessArgs(args []string) []string {
+	out := make([]string, 0, len(args))
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if (arg == "-r" || arg == "--r") && i+1 < len(args) {
+			if n, err := strconv.Atoi(args[i+1]); err == nil {
+				out = append(out, fmt.Sprintf("%s=%d", arg, n))
+				i++
+				continue
+			}
+		}
+		out = append(out, arg)
+	}
+	return out
 }
 
-func getBranchInfo(base string) [][]string {
-	dirs, err := os.ReadDir(base)
-	if err != nil {
-		panic(err)
+// defaultDirs picks the base directories to scan when none are given on the
+// command line: the SHOWBRANCHES_DEFAULT env var wins if set, then the
+// config file's base entries, then the current directory.
+func defaultDirs() []string {
+	fallback := cfg.Base
+	if len(fallback) == 0 {
+		fallback = []string{"."}
 	}
-	var data [][]string
-	data = append(data, header)
-	for _, dir := range dirs {
-		if dir.IsDir() {
-			r, err := git.PlainOpen(path.Join(base, dir.Name()))
-			if err != nil {
-				if errors.Is(err, git.ErrRepositoryNotExists) {
-					continue
-				}
-				panic(err)
-			}
-			c, err := r.Config()
-			if err != nil {
-				panic(err)
-			}
-
-			d := make([]string, 4)
-
-			d[DirHeader] = dir.Name()
-			if dir.Name() == ".git" {
-				p, err := filepath.Abs(dir.Name())
-				if err != nil {
-					panic(err) // shouldn't get an error trying to get full path
-				}
-				parts := strings.Split(p, string(os.PathSeparator))
-				d[DirHeader] = parts[len(parts)-2]
-			}
-			if c.Remotes["origin"] != nil && len(c.Remotes["origin"].URLs) > 0 {
-				d[RepoHeader] = c.Remotes["origin"].URLs[0]
-			} else {
-				if !showNoRemotes {
-					continue
-				}
-				d[RepoHeader] = "<no remote>"
-			}
-			ref, err := r.Reference("refs/remotes/origin/HEAD", false)
-			if err != nil {
-				if errors.Is(err, plumbing.ErrReferenceNotFound) {
-					if !showNoRemotes {
-						continue
-					}
-					d[MainHeader] = "<no remote>"
-				} else {
-					panic(err)
-				}
-			} else {
-				d[MainHeader] = last(ref.Target().String(), "/")
-			}
-
-			h, err := r.Head()
-			if err != nil {
-				if errors.Is(err, plumbing.ErrReferenceNotFound) {
-					d[CurrHeader] = "<no branch>"
-				} else {
-					panic(err)
-				}
-			} else {
-				d[CurrHeader] = last(h.Name().String(), "/")
-			}
+	return env.StringSliceOrDefault("SHOWBRANCHES_DEFAULT", " ", fallback)
+}
 
-			if !onlyShowDiffs || !strings.EqualFold(d[MainHeader], d[CurrHeader]) {
-				data = append(data, d)
-			}
-		}
+func printData(base string) {
+	infos := getBranchInfo(base)
+	if err := render(outputFormat, infos); err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
 	}
-	return data
 }
 
 func last(s, sep string) string {
@@ -0,0 +1,182 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/pterm/pterm"
+)
+
+// outputFormats lists the values accepted by -o.
+var outputFormats = []string{"table", "json", "csv", "logfmt"}
+
+// render writes infos to stdout in the given format. An unknown format is a
+// usage error, not a panic, since it's something a caller can simply fix.
+func render(format string, infos []RepoInfo) error {
+	switch format {
+	case "table":
+		renderTable(infos)
+	case "json":
+		return renderJSON(infos)
+	case "csv":
+		return renderCSV(infos)
+	case "logfmt":
+		renderLogfmt(infos)
+	default:
+		return fmt.Errorf("unknown output format %q (want one of %s)", format, strings.Join(outputFormats, ", "))
+	}
+	return nil
+}
+
+// renderTable prints infos as the existing pterm table, with the columns
+// chosen by tableColumns: the ahead/behind and dirty columns only appear when
+// -s was given, and the status/error column is last unless the config file's
+// columns= entry picks a different order.
+func renderTable(infos []RepoInfo) {
+	data := [][]string{tableHeader()}
+	for _, info := range infos {
+		data = append(data, tableRow(info))
+	}
+	_ = pterm.DefaultTable.WithHasHeader().WithBoxed().WithData(data).Render()
+}
+
+// tableColumn is one column of table output: its config.Columns key, its
+// header label, and how to render it for a given RepoInfo.
+type tableColumn struct {
+	key   string
+	label string
+	cell  func(info RepoInfo) string
+}
+
+// tableColumns returns the columns to render, in order: the config file's
+// columns= preference if it names at least one recognized column, otherwise
+// the default set (ahead/behind and dirty only when -s is given, status
+// always last).
+func tableColumns() []tableColumn {
+	all := []tableColumn{
+		{"directory", "Directory", func(info RepoInfo) string { return info.Directory }},
+		{"repo", "Repo", func(info RepoInfo) string { return info.Repo }},
+		{"main", "Main Branch", func(info RepoInfo) string { return info.MainBranch }},
+		{"current", "Current Branch", func(info RepoInfo) string { return info.CurrentBranch }},
+	}
+	if statusMode {
+		all = append(all,
+			tableColumn{"ahead-behind", "Ahead/Behind", func(info RepoInfo) string {
+				if info.Error != "" {
+					return ""
+				}
+				return fmt.Sprintf("↑%d ↓%d", info.Ahead, info.Behind)
+			}},
+			tableColumn{"dirty", "Dirty", func(info RepoInfo) string {
+				if !info.Dirty {
+					return ""
+				}
+				return "✱"
+			}},
+		)
+	}
+	all = append(all, tableColumn{"status", "Status", func(info RepoInfo) string { return info.Error }})
+
+	if len(cfg.Columns) == 0 {
+		return all
+	}
+
+	byKey := make(map[string]tableColumn, len(all))
+	for _, c := range all {
+		byKey[c.key] = c
+	}
+
+	var selected []tableColumn
+	for _, want := range cfg.Columns {
+		if c, ok := byKey[strings.ToLower(strings.TrimSpace(want))]; ok {
+			selected = append(selected, c)
+		}
+	}
+	if len(selected) == 0 {
+		return all
+	}
+	return selected
+}
+
+// tableHeader returns the column headers for the current flags and config.
+func tableHeader() []string {
+	cols := tableColumns()
+	h := make([]string, len(cols))
+	for i, c := range cols {
+		h[i] = c.label
+	}
+	return h
+}
+
+// tableRow renders info in the same column order as tableHeader.
+func tableRow(info RepoInfo) []string {
+	cols := tableColumns()
+	row := make([]string, len(cols))
+	for i, c := range cols {
+		row[i] = c.cell(info)
+	}
+	return row
+}
+
+// renderJSON writes infos as a pretty-printed JSON array, one RepoInfo per
+// element, suitable for piping into jq.
+func renderJSON(infos []RepoInfo) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(infos)
+}
+
+// renderCSV writes infos as CSV with a RepoInfo-shaped header row.
+func renderCSV(infos []RepoInfo) error {
+	w := csv.NewWriter(os.Stdout)
+	defer w.Flush()
+
+	if err := w.Write([]string{"Directory", "Repo", "MainBranch", "CurrentBranch", "Ahead", "Behind", "Dirty", "Error"}); err != nil {
+		return err
+	}
+	for _, info := range infos {
+		record := []string{
+			info.Directory,
+			info.Repo,
+			info.MainBranch,
+			info.CurrentBranch,
+			strconv.Itoa(info.Ahead),
+			strconv.Itoa(info.Behind),
+			strconv.FormatBool(info.Dirty),
+			info.Error,
+		}
+		if err := w.Write(record); err != nil {
+			return err
+		}
+	}
+	return w.Error()
+}
+
+// renderLogfmt writes infos one line per repository as space-separated
+// key=value pairs, quoting values that need it.
+func renderLogfmt(infos []RepoInfo) {
+	for _, info := range infos {
+		fmt.Println(strings.Join([]string{
+			"directory=" + logfmtValue(info.Directory),
+			"repo=" + logfmtValue(info.Repo),
+			"main=" + logfmtValue(info.MainBranch),
+			"current=" + logfmtValue(info.CurrentBranch),
+			"ahead=" + strconv.Itoa(info.Ahead),
+			"behind=" + strconv.Itoa(info.Behind),
+			"dirty=" + strconv.FormatBool(info.Dirty),
+			"error=" + logfmtValue(info.Error),
+		}, " "))
+	}
+}
+
+// logfmtValue quotes s if it's empty or contains whitespace/quotes.
+func logfmtValue(s string) string {
+	if s == "" || strings.ContainsAny(s, " \t\r\n\"") {
+		return strconv.Quote(s)
+	}
+	return s
+}
@@ -0,0 +1,15 @@
+package main
+
+// RepoInfo is the result of scanning a single repository. It's the common
+// shape every output renderer (table, JSON, CSV, logfmt) works from, so they
+// all report the same data.
+type RepoInfo struct {
+	Directory     string
+	Repo          string
+	MainBranch    string
+	CurrentBranch string
+	Ahead         int
+	Behind        int
+	Dirty         bool
+	Error         string
+}
@@ -0,0 +1,259 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/format/gitignore"
+	"github.com/pterm/pterm"
+)
+
+// repoCandidate is a directory found during the (fast, serial) filesystem
+// walk that looks like a git repository and is queued up for the (slower,
+// concurrent) worker pool to open and inspect.
+type repoCandidate struct {
+	path string
+	name string
+}
+
+// scanResult carries a candidate's RepoInfo back to the aggregator, tagged
+// with its original index so results can be placed in stable, input order
+// even though the workers finish out of order.
+type scanResult struct {
+	index int
+	info  *RepoInfo
+}
+
+// getBranchInfo walks base (and, when -r is set, its subdirectories) looking
+// for git repositories, then fans the repositories found out to a worker
+// pool to extract their branch and (optionally) status info.
+func getBranchInfo(base string) []RepoInfo {
+	matcher := loadIgnorePatterns(base, cfg.Ignore)
+
+	var candidates []repoCandidate
+	collectRepos(base, base, 0, matcher, &candidates)
+
+	results := scanCandidates(candidates)
+
+	var infos []RepoInfo
+	for _, info := range results {
+		if info != nil {
+			infos = append(infos, *info)
+		}
+	}
+	return infos
+}
+
+// scanCandidates opens and inspects each candidate using a worker pool bound
+// by concurrency, reporting progress on a spinner as results come in. The
+// returned slice is in the same order as candidates; a nil entry means the
+// row was filtered out (not an error).
+func scanCandidates(candidates []repoCandidate) []*RepoInfo {
+	results := make([]*RepoInfo, len(candidates))
+	if len(candidates) == 0 {
+		return results
+	}
+
+	// The spinner always goes to stderr, never stdout: -o json/csv/logfmt
+	// pipe stdout straight into jq/a spreadsheet/a log shipper, and a
+	// progress banner ahead of the payload would break that.
+	spinner, _ := pterm.DefaultSpinner.WithWriter(os.Stderr).Start(fmt.Sprintf("scanned 0 of %d", len(candidates)))
+
+	jobs := make(chan int)
+	out := make(chan scanResult, len(candidates))
+
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for idx := range jobs {
+				out <- scanResult{index: idx, info: scanRepo(candidates[idx])}
+			}
+		}()
+	}
+
+	go func() {
+		for i := range candidates {
+			jobs <- i
+		}
+		close(jobs)
+	}()
+
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+
+	done := 0
+	for res := range out {
+		results[res.index] = res.info
+		done++
+		spinner.UpdateText(fmt.Sprintf("scanned %d of %d", done, len(candidates)))
+	}
+
+	spinner.Success(fmt.Sprintf("scanned %d of %d", done, len(candidates)))
+	return results
+}
+
+// collectRepos queues dir itself if it has a .git directory, and every
+// subdirectory of dir that is a git repository; subdirectories that aren't
+// repositories are recursed into as long as depth is within recurseDepth's
+// limit (recurseDepth.n < 0 means unlimited). A repository's own
+// subdirectories are still checked, so repos nested inside base are listed
+// too. Directories that can't be read are skipped rather than aborting the
+// whole scan.
+func collectRepos(base, dir string, depth int, matcher gitignore.Matcher, candidates *[]repoCandidate) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return
+	}
+
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+
+		full := path.Join(dir, entry.Name())
+		rel, err := filepath.Rel(base, full)
+		if err != nil {
+			continue
+		}
+
+		if matcher != nil && entry.Name() != ".git" &&
+			matcher.Match(strings.Split(rel, string(os.PathSeparator)), true) {
+			continue
+		}
+
+		if entry.Name() == ".git" {
+			// base itself is the repo; show it under its own directory name
+			// rather than under ".git", and open its parent (the worktree),
+			// not the .git dir itself, so it isn't mistaken for a bare repo.
+			name := filepath.Dir(rel)
+			if name == "." {
+				name = filepath.Base(base)
+			}
+			*candidates = append(*candidates, repoCandidate{path: dir, name: name})
+			continue
+		}
+
+		if isRepoDir(full) {
+			*candidates = append(*candidates, repoCandidate{path: full, name: rel})
+			continue
+		}
+
+		if recurseDepth.n < 0 || depth < recurseDepth.n {
+			collectRepos(base, full, depth+1, matcher, candidates)
+		}
+	}
+}
+
+// isRepoDir reports whether dir looks like the working copy of a git
+// repository, without the cost of fully opening it.
+func isRepoDir(dir string) bool {
+	_, err := os.Stat(filepath.Join(dir, ".git"))
+	return err == nil
+}
+
+// scanRepo opens c and extracts its remote, main branch, current branch and
+// (when -s is set) ahead/behind/dirty status, honoring showNoRemotes and
+// onlyShowDiffs. A nil return means the row should be skipped; errors are
+// reported on RepoInfo.Error rather than panicking, so one bad repo doesn't
+// kill the whole scan.
+func scanRepo(c repoCandidate) *RepoInfo {
+	info := &RepoInfo{Directory: c.name}
+
+	r, err := git.PlainOpen(c.path)
+	if err != nil {
+		info.Error = err.Error()
+		return info
+	}
+
+	repoCfg, err := r.Config()
+	if err != nil {
+		info.Error = err.Error()
+		return info
+	}
+
+	if repoCfg.Remotes["origin"] != nil && len(repoCfg.Remotes["origin"].URLs) > 0 {
+		info.Repo = repoCfg.Remotes["origin"].URLs[0]
+	} else {
+		if !showNoRemotes {
+			return nil
+		}
+		info.Repo = "<no remote>"
+	}
+
+	if fetchMode && info.Repo != "" && info.Repo != "<no remote>" {
+		if err := fetchRepo(r, info.Repo, fetchTimeout); err != nil {
+			info.Error = err.Error()
+			return info
+		}
+	}
+
+	var mainRef *plumbing.Reference
+	ref, err := r.Reference("refs/remotes/origin/HEAD", false)
+	if err != nil {
+		if errors.Is(err, plumbing.ErrReferenceNotFound) {
+			if !showNoRemotes {
+				return nil
+			}
+			info.MainBranch = "<no remote>"
+		} else {
+			info.Error = err.Error()
+			return info
+		}
+	} else {
+		info.MainBranch = last(ref.Target().String(), "/")
+		if mainRef, err = r.Reference(ref.Target(), true); err != nil {
+			info.Error = err.Error()
+			return info
+		}
+	}
+
+	var currRef *plumbing.Reference
+	h, err := r.Head()
+	if err != nil {
+		if errors.Is(err, plumbing.ErrReferenceNotFound) {
+			info.CurrentBranch = "<no branch>"
+		} else {
+			info.Error = err.Error()
+			return info
+		}
+	} else {
+		info.CurrentBranch = last(h.Name().String(), "/")
+		currRef = h
+	}
+
+	if onlyShowDiffs && strings.EqualFold(info.MainBranch, info.CurrentBranch) {
+		return nil
+	}
+
+	if statusMode {
+		d, err := dirty(r)
+		if err != nil {
+			info.Error = err.Error()
+			return info
+		}
+		info.Dirty = d
+
+		if mainRef != nil && currRef != nil {
+			ahead, behind, err := aheadBehind(r, mainRef.Hash(), currRef.Hash())
+			if err != nil {
+				info.Error = err.Error()
+				return info
+			}
+			info.Ahead = ahead
+			info.Behind = behind
+		}
+	}
+
+	return info
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/storer"
+)
+
+// aheadBehind computes how far currHash is ahead/behind mainHash.
+func aheadBehind(r *git.Repository, mainHash, currHash plumbing.Hash) (ahead, behind int, err error) {
+	mainCommit, err := r.CommitObject(mainHash)
+	if err != nil {
+		return 0, 0, err
+	}
+	currCommit, err := r.CommitObject(currHash)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	bases, err := currCommit.MergeBase(mainCommit)
+	if err != nil {
+		return 0, 0, err
+	}
+	if len(bases) == 0 {
+		return 0, 0, fmt.Errorf("no common ancestor between %s and %s", mainHash, currHash)
+	}
+	base := bases[0]
+
+	if ahead, err = commitsUntil(r, currCommit, base); err != nil {
+		return 0, 0, err
+	}
+	if behind, err = commitsUntil(r, mainCommit, base); err != nil {
+		return 0, 0, err
+	}
+	return ahead, behind, nil
+}
+
+// dirty reports whether r's worktree has uncommitted changes or untracked
+// files. It doesn't depend on any particular ref being resolvable, so it
+// still works for repos with no origin/HEAD (no remote, or a remote whose
+// HEAD symref was never set).
+func dirty(r *git.Repository) (bool, error) {
+	wt, err := r.Worktree()
+	if err != nil {
+		if errors.Is(err, git.ErrIsBareRepository) {
+			return false, nil
+		}
+		return false, err
+	}
+	st, err := wt.Status()
+	if err != nil {
+		return false, err
+	}
+	return !st.IsClean(), nil
+}
+
+// commitsUntil counts the commits reachable from from, stopping (exclusive)
+// at stop, which is expected to be a (merge-base) ancestor of from.
+func commitsUntil(r *git.Repository, from, stop *object.Commit) (int, error) {
+	iter, err := r.Log(&git.LogOptions{From: from.Hash})
+	if err != nil {
+		return 0, err
+	}
+	defer iter.Close()
+
+	count := 0
+	err = iter.ForEach(func(c *object.Commit) error {
+		if c.Hash == stop.Hash {
+			return storer.ErrStop
+		}
+		count++
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
@@ -0,0 +1,150 @@
+package env
+
+import (
+	"os"
+	"reflect"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Parser converts a raw environment variable string into a T, returning an
+// error if the value can't be parsed.
+type Parser[T any] func(string) (T, error)
+
+// registry holds user-registered parsers, keyed by the parsed type.
+// Guarded by registryMu since Register and lookups (Get/Lookup) may run
+// concurrently, e.g. from a caller's worker pool.
+var (
+	registryMu sync.RWMutex
+	registry   = map[reflect.Type]any{}
+)
+
+// Register adds a parser for a user-defined type T (a URL, a log level, a
+// byte size, ...) so it can be used with Get and Lookup. The built-in types
+// (string, bool, int, int64, float32, float64, time.Duration) already work
+// without registering anything; registering one of them overrides the
+// built-in parser.
+func Register[T any](parse Parser[T]) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[typeKey[T]()] = parse
+}
+
+// Get returns the value in the system environment denoted by key, parsed as
+// a T, or defaultValue if key isn't set or doesn't parse.
+func Get[T any](key string, defaultValue T) T {
+	v, ok := Lookup[T](key)
+	if !ok {
+		return defaultValue
+	}
+	return v
+}
+
+// Lookup returns the value in the system environment denoted by key, parsed
+// as a T, and true. If key isn't set, or there's no parser registered for T,
+// or the value doesn't parse, it returns the zero value of T and false.
+func Lookup[T any](key string) (T, bool) {
+	var zero T
+
+	envVal, ok := os.LookupEnv(key)
+	if !ok {
+		return zero, false
+	}
+
+	parse, ok := parserFor[T]()
+	if !ok {
+		return zero, false
+	}
+
+	v, err := parse(envVal)
+	if err != nil {
+		return zero, false
+	}
+	return v, true
+}
+
+// parserFor resolves the Parser to use for T: a registered one if present,
+// otherwise the built-in one, if any.
+func parserFor[T any]() (Parser[T], bool) {
+	registryMu.RLock()
+	p, ok := registry[typeKey[T]()]
+	registryMu.RUnlock()
+
+	if ok {
+		if parser, ok := p.(Parser[T]); ok {
+			return parser, true
+		}
+	}
+	return builtinParser[T]()
+}
+
+// builtinParser returns the Parser for one of the types Get/Lookup support
+// out of the box.
+func builtinParser[T any]() (Parser[T], bool) {
+	var zero T
+
+	switch any(zero).(type) {
+	case string:
+		return func(s string) (T, error) {
+			return any(s).(T), nil
+		}, true
+	case bool:
+		return func(s string) (T, error) {
+			b, err := strconv.ParseBool(s)
+			if err != nil {
+				return zero, err
+			}
+			return any(b).(T), nil
+		}, true
+	case int:
+		return func(s string) (T, error) {
+			n, err := strconv.Atoi(s)
+			if err != nil {
+				return zero, err
+			}
+			return any(n).(T), nil
+		}, true
+	case int64:
+		return func(s string) (T, error) {
+			n, err := strconv.ParseInt(s, 10, 64)
+			if err != nil {
+				return zero, err
+			}
+			return any(n).(T), nil
+		}, true
+	case float32:
+		return func(s string) (T, error) {
+			f, err := strconv.ParseFloat(s, 32)
+			if err != nil {
+				return zero, err
+			}
+			return any(float32(f)).(T), nil
+		}, true
+	case float64:
+		return func(s string) (T, error) {
+			f, err := strconv.ParseFloat(s, 64)
+			if err != nil {
+				return zero, err
+			}
+			return any(f).(T), nil
+		}, true
+	case time.Duration:
+		return func(s string) (T, error) {
+			d, err := time.ParseDuration(s)
+			if err != nil {
+				return zero, err
+			}
+			return any(d).(T), nil
+		}, true
+	default:
+		return nil, false
+	}
+}
+
+// typeKey returns a stable, unique key for T to use as a registry/lookup
+// key, since T itself can't be used as a map key. Going through *T keeps
+// interface types distinct, whose nil zero values carry no type.
+func typeKey[T any]() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
@@ -0,0 +1,92 @@
+package env
+
+import (
+	"os"
+	"strconv"
+	"strings"
+)
+
+// StringSliceOrDefault returns the value in the system environment denoted
+// by key split on sep, or defaultValue if there is no environment variable
+// named key. Fields are trimmed of surrounding whitespace; double-quoting a
+// field ("a b", c) lets it contain sep or spaces without being split.
+func StringSliceOrDefault(key, sep string, defaultValue []string) []string {
+	envVal, ok := os.LookupEnv(key)
+	if !ok {
+		return defaultValue
+	}
+	return splitQuoted(envVal, sep)
+}
+
+// IntSliceOrDefault is StringSliceOrDefault for ints; if any field fails to
+// parse, the whole value is rejected in favor of defaultValue.
+func IntSliceOrDefault(key, sep string, defaultValue []int) []int {
+	envVal, ok := os.LookupEnv(key)
+	if !ok {
+		return defaultValue
+	}
+
+	fields := splitQuoted(envVal, sep)
+	ints := make([]int, len(fields))
+	for i, f := range fields {
+		n, err := strconv.Atoi(f)
+		if err != nil {
+			return defaultValue
+		}
+		ints[i] = n
+	}
+	return ints
+}
+
+// StringMapOrDefault returns the value in the system environment denoted by
+// key, parsed as pairSep-separated "k<kvSep>v" entries (e.g. "a=1,b=2" with
+// pairSep "," and kvSep "="), or defaultValue if key isn't set or any entry
+// is missing kvSep.
+func StringMapOrDefault(key, pairSep, kvSep string, defaultValue map[string]string) map[string]string {
+	envVal, ok := os.LookupEnv(key)
+	if !ok {
+		return defaultValue
+	}
+
+	m := make(map[string]string)
+	for _, pair := range splitQuoted(envVal, pairSep) {
+		k, v, found := strings.Cut(pair, kvSep)
+		if !found {
+			return defaultValue
+		}
+		m[strings.TrimSpace(k)] = strings.TrimSpace(v)
+	}
+	return m
+}
+
+// splitQuoted splits s on sep like strings.Split, except double-quoted
+// fields are kept intact (so sep, or spaces, inside quotes survive), and
+// surrounding whitespace is trimmed from every field.
+func splitQuoted(s, sep string) []string {
+	var fields []string
+	var cur strings.Builder
+	inQuotes := false
+
+	flush := func() {
+		fields = append(fields, strings.TrimSpace(cur.String()))
+		cur.Reset()
+	}
+
+	for i := 0; i < len(s); {
+		if s[i] == '"' {
+			inQuotes = !inQuotes
+			i++
+			continue
+		}
+		if !inQuotes && strings.HasPrefix(s[i:], sep) {
+			flush()
+			i += len(sep)
+			continue
+		}
+		cur.WriteByte(s[i])
+		i++
+	}
+	flush()
+
+	return fields
+}